@@ -0,0 +1,157 @@
+package musicbrainz
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/teal-fm/piper/models"
+)
+
+type acoustIDLookupResponse struct {
+	Status string `json:"status"`
+	Error  *struct {
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+	Results []struct {
+		ID         string  `json:"id"`
+		Score      float64 `json:"score"`
+		Recordings []struct {
+			ID      string `json:"id"`
+			Title   string `json:"title"`
+			Artists []struct {
+				ID   string `json:"id"`
+				Name string `json:"name"`
+			} `json:"artists"`
+			Releases []struct {
+				ID    string `json:"id"`
+				Title string `json:"title"`
+			} `json:"releases"`
+		} `json:"recordings"`
+	} `json:"results"`
+}
+
+// resolveFingerprint calls the AcoustID web service with a Chromaprint fingerprint
+// and duration, takes the highest-scoring match, and looks it up through
+// LookupRecording so the result carries the same ISRCs/best-release data a
+// name-based search would.
+func (mb *MusicBrainzService) resolveFingerprint(ctx context.Context, fingerprint string, durationSec int) (*Candidate, error) {
+	if mb.acoustIDAPIKey == "" {
+		return nil, fmt.Errorf("musicbrainz: AcoustID lookup requires an API key (see WithAcoustIDAPIKey)")
+	}
+	if fingerprint == "" {
+		return nil, fmt.Errorf("musicbrainz: fingerprint must not be empty")
+	}
+
+	q := url.Values{}
+	q.Set("client", mb.acoustIDAPIKey)
+	q.Set("meta", "recordings+releases+tracks")
+	q.Set("duration", strconv.Itoa(durationSec))
+	q.Set("fingerprint", fingerprint)
+	endpoint := "https://api.acoustid.org/v2/lookup?" + q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", mb.userAgent)
+
+	resp, err := doRequestWithRetry(ctx, mb.httpClient, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request to %s: %w", endpoint, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("AcoustID API request to %s returned status %d", endpoint, resp.StatusCode)
+	}
+
+	var result acoustIDLookupResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response from %s: %w", endpoint, err)
+	}
+	if result.Status != "ok" {
+		msg := "unknown error"
+		if result.Error != nil {
+			msg = result.Error.Message
+		}
+		return nil, fmt.Errorf("AcoustID lookup failed: %s", msg)
+	}
+	if len(result.Results) == 0 {
+		return nil, errors.New("no AcoustID matches found")
+	}
+
+	best := result.Results[0]
+	for _, r := range result.Results[1:] {
+		if r.Score > best.Score {
+			best = r
+		}
+	}
+	if len(best.Recordings) == 0 {
+		return nil, errors.New("AcoustID match had no associated recordings")
+	}
+	rec := best.Recordings[0]
+
+	if mbRec, err := mb.LookupRecording(ctx, rec.ID); err == nil && mbRec != nil {
+		candidate := recordingToCandidate(acoustIDProviderName, acoustIDProviderPriority, *mbRec)
+		return &candidate, nil
+	}
+
+	// LookupRecording failed (e.g. offline/rate-limited); fall back to the thinner
+	// data AcoustID itself returned rather than failing the whole lookup.
+	artists := make([]models.Artist, len(rec.Artists))
+	for i, a := range rec.Artists {
+		artists[i] = models.Artist{Name: a.Name, ID: a.ID, MBID: a.ID}
+	}
+	var album, releaseMBID string
+	if len(rec.Releases) > 0 {
+		album = rec.Releases[0].Title
+		releaseMBID = rec.Releases[0].ID
+	}
+
+	return &Candidate{
+		Provider:      acoustIDProviderName,
+		Priority:      acoustIDProviderPriority,
+		RecordingMBID: rec.ID,
+		ReleaseMBID:   releaseMBID,
+		Title:         rec.Title,
+		Album:         album,
+		Artists:       artists,
+		DurationMs:    int64(durationSec) * 1000,
+	}, nil
+}
+
+// HydrateTrackByFingerprint resolves a track from a raw Chromaprint fingerprint
+// instead of a name search. This is the path for stream/scrobble sources that
+// hand back garbage titles no name-based query can match: callers fingerprint the
+// audio themselves and hand the result here. Requires an AcoustID API key (see
+// WithAcoustIDAPIKey).
+func (mb *MusicBrainzService) HydrateTrackByFingerprint(ctx context.Context, fingerprint string, durationSec int) (*models.Track, error) {
+	candidate, err := mb.resolveFingerprint(ctx, fingerprint, durationSec)
+	if err != nil {
+		return nil, err
+	}
+
+	track := &models.Track{
+		Name:          candidate.Title,
+		RecordingMBID: candidate.RecordingMBID,
+		Album:         candidate.Album,
+		ReleaseMBID:   candidate.ReleaseMBID,
+		ISRC:          candidate.ISRC,
+		Artist:        candidate.Artists,
+		DurationMs:    candidate.DurationMs,
+	}
+
+	for _, p := range mb.providers {
+		if err := p.Enrich(ctx, track); err != nil {
+			log.Printf("musicbrainz: provider %s enrich failed: %v", p.Name(), err)
+		}
+	}
+
+	return track, nil
+}
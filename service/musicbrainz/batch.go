@@ -0,0 +1,85 @@
+package musicbrainz
+
+import (
+	"context"
+
+	"github.com/teal-fm/piper/models"
+)
+
+// HydrateSource records which path produced a HydrateResult's data.
+type HydrateSource string
+
+const (
+	HydrateSourceCache       HydrateSource = "cache"
+	HydrateSourceNetwork     HydrateSource = "net"
+	HydrateSourceFingerprint HydrateSource = "fingerprint"
+)
+
+// HydrateResult is the per-input outcome of a HydrateTracks call.
+type HydrateResult struct {
+	Track  models.Track
+	Err    error
+	Source HydrateSource
+	Score  float64
+}
+
+// HydrateTracks hydrates many tracks while respecting MusicBrainz's 1 req/sec
+// budget. This is the realistic shape needed when importing a listening-history
+// backlog: calling HydrateTrack in goroutines would violate the rate limit, and
+// serializing by hand means every caller reinvents the same dedup logic. Plays of
+// the same track (the common case in a backlog) share a search cache key, so they
+// resolve to a single lookup - already-cached entries are served immediately, and
+// the rest drain serially through SearchMusicBrainz's existing rate.Limiter,
+// which itself coalesces concurrent identical requests via singleflight.
+func (mb *MusicBrainzService) HydrateTracks(ctx context.Context, tracks []models.Track) ([]HydrateResult, error) {
+	results := make([]HydrateResult, len(tracks))
+
+	type group struct {
+		indices []int
+	}
+	groups := make(map[string]*group, len(tracks))
+	order := make([]string, 0, len(tracks))
+
+	for i, track := range tracks {
+		key := mb.searchCacheKeyFor(track)
+		g, ok := groups[key]
+		if !ok {
+			g = &group{}
+			groups[key] = g
+			order = append(order, key)
+		}
+		g.indices = append(g.indices, i)
+	}
+
+	for _, key := range order {
+		g := groups[key]
+		representative := tracks[g.indices[0]]
+
+		wasCached := mb.peekSearchCache(ctx, key)
+
+		hydrated, score, source, err := mb.hydrate(ctx, representative, HydrateOptions{})
+		if err == nil && wasCached && source != HydrateSourceFingerprint {
+			source = HydrateSourceCache
+		}
+
+		for _, idx := range g.indices {
+			if err != nil {
+				results[idx] = HydrateResult{Track: tracks[idx], Err: err, Source: source}
+				continue
+			}
+			// Copy the per-play fields back in: the hydrated metadata is shared
+			// across the group, but each play is still its own event.
+			track := *hydrated
+			track.HasStamped = tracks[idx].HasStamped
+			track.PlayID = tracks[idx].PlayID
+			track.URL = tracks[idx].URL
+			track.ServiceBaseUrl = tracks[idx].ServiceBaseUrl
+			track.Timestamp = tracks[idx].Timestamp
+			track.ProgressMs = tracks[idx].ProgressMs
+
+			results[idx] = HydrateResult{Track: track, Score: score, Source: source}
+		}
+	}
+
+	return results, nil
+}
@@ -0,0 +1,299 @@
+package musicbrainz
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	// defaultSearchCacheTTL is how long fuzzy text searches stay cached. Search
+	// relevance doesn't change often and MB's rate limit makes re-querying costly.
+	defaultSearchCacheTTL = 1 * time.Hour
+
+	// defaultRecordingCacheTTL is how long a hydrated recording->release binding
+	// stays cached. Canonical recording metadata changes rarely, so this is long-lived.
+	defaultRecordingCacheTTL = 7 * 24 * time.Hour
+
+	// cachePruneInterval controls how often expired rows are swept from the DB cache.
+	cachePruneInterval = 15 * time.Minute
+)
+
+// ensureCacheSchema creates the on-disk cache tables if they don't already exist.
+func (s *MusicBrainzService) ensureCacheSchema(ctx context.Context) error {
+	stmts := []string{
+		`CREATE TABLE IF NOT EXISTS musicbrainz_cache (
+			cache_key TEXT PRIMARY KEY,
+			response_json BLOB NOT NULL,
+			expires_at INTEGER NOT NULL
+		)`,
+		`CREATE TABLE IF NOT EXISTS musicbrainz_recording_cache (
+			recording_mbid TEXT PRIMARY KEY,
+			response_json BLOB NOT NULL,
+			expires_at INTEGER NOT NULL,
+			etag TEXT NOT NULL DEFAULT '',
+			last_modified TEXT NOT NULL DEFAULT ''
+		)`,
+	}
+	for _, stmt := range stmts {
+		if _, err := s.db.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("musicbrainz: create cache table: %w", err)
+		}
+	}
+
+	// musicbrainz_recording_cache predates the etag/last_modified columns, so
+	// databases created before conditional-request support need them added on.
+	// Ignore errors: sqlite has no ADD COLUMN IF NOT EXISTS, and this fails
+	// harmlessly once the columns already exist.
+	for _, stmt := range []string{
+		`ALTER TABLE musicbrainz_recording_cache ADD COLUMN etag TEXT NOT NULL DEFAULT ''`,
+		`ALTER TABLE musicbrainz_recording_cache ADD COLUMN last_modified TEXT NOT NULL DEFAULT ''`,
+	} {
+		s.db.ExecContext(ctx, stmt)
+	}
+
+	return nil
+}
+
+// dbGetSearch looks up a previously cached search response in the DB cache.
+func (s *MusicBrainzService) dbGetSearch(ctx context.Context, cacheKey string) ([]MusicBrainzRecording, bool) {
+	if s.db == nil {
+		return nil, false
+	}
+	var blob []byte
+	var expiresAt int64
+	row := s.db.QueryRowContext(ctx, `SELECT response_json, expires_at FROM musicbrainz_cache WHERE cache_key = ?`, cacheKey)
+	if err := row.Scan(&blob, &expiresAt); err != nil {
+		if err != sql.ErrNoRows {
+			log.Printf("musicbrainz: db search cache lookup failed for key=%s: %v", cacheKey, err)
+		}
+		return nil, false
+	}
+	if time.Now().Unix() >= expiresAt {
+		return nil, false
+	}
+	var recordings []MusicBrainzRecording
+	if err := json.Unmarshal(blob, &recordings); err != nil {
+		log.Printf("musicbrainz: failed to decode cached search result for key=%s: %v", cacheKey, err)
+		return nil, false
+	}
+	return recordings, true
+}
+
+// dbPutSearch persists a search response to the DB cache with the given TTL.
+func (s *MusicBrainzService) dbPutSearch(ctx context.Context, cacheKey string, recordings []MusicBrainzRecording, ttl time.Duration) {
+	if s.db == nil {
+		return
+	}
+	blob, err := json.Marshal(recordings)
+	if err != nil {
+		log.Printf("musicbrainz: failed to encode search result for key=%s: %v", cacheKey, err)
+		return
+	}
+	expiresAt := time.Now().Add(ttl).Unix()
+	if _, err := s.db.ExecContext(ctx, `
+		INSERT INTO musicbrainz_cache (cache_key, response_json, expires_at)
+		VALUES (?, ?, ?)
+		ON CONFLICT(cache_key) DO UPDATE SET response_json = excluded.response_json, expires_at = excluded.expires_at
+	`, cacheKey, blob, expiresAt); err != nil {
+		log.Printf("musicbrainz: failed to persist search result for key=%s: %v", cacheKey, err)
+	}
+}
+
+// peekSearchCache reports whether cacheKey is currently present and unexpired in
+// either the in-memory or DB search cache, without performing any network call.
+func (s *MusicBrainzService) peekSearchCache(ctx context.Context, cacheKey string) bool {
+	s.cacheMutex.RLock()
+	entry, found := s.searchCache[cacheKey]
+	s.cacheMutex.RUnlock()
+	if found && time.Now().Before(entry.expiresAt) {
+		return true
+	}
+	_, ok := s.dbGetSearch(ctx, cacheKey)
+	return ok
+}
+
+// dbGetRecording looks up a previously cached recording lookup in the DB cache.
+func (s *MusicBrainzService) dbGetRecording(ctx context.Context, mbid string) (*MusicBrainzRecording, bool) {
+	if s.db == nil {
+		return nil, false
+	}
+	var blob []byte
+	var expiresAt int64
+	row := s.db.QueryRowContext(ctx, `SELECT response_json, expires_at FROM musicbrainz_recording_cache WHERE recording_mbid = ?`, mbid)
+	if err := row.Scan(&blob, &expiresAt); err != nil {
+		if err != sql.ErrNoRows {
+			log.Printf("musicbrainz: db recording cache lookup failed for mbid=%s: %v", mbid, err)
+		}
+		return nil, false
+	}
+	if time.Now().Unix() >= expiresAt {
+		return nil, false
+	}
+	var rec MusicBrainzRecording
+	if err := json.Unmarshal(blob, &rec); err != nil {
+		log.Printf("musicbrainz: failed to decode cached recording for mbid=%s: %v", mbid, err)
+		return nil, false
+	}
+	return &rec, true
+}
+
+// dbPutRecording persists a recording lookup to the DB cache with the given TTL,
+// along with the validators (if any) MusicBrainz returned so a future expiry can
+// be revalidated with a conditional request instead of an unconditional refetch.
+func (s *MusicBrainzService) dbPutRecording(ctx context.Context, mbid string, rec MusicBrainzRecording, ttl time.Duration, etag, lastModified string) {
+	if s.db == nil {
+		return
+	}
+	blob, err := json.Marshal(rec)
+	if err != nil {
+		log.Printf("musicbrainz: failed to encode recording for mbid=%s: %v", mbid, err)
+		return
+	}
+	expiresAt := time.Now().Add(ttl).Unix()
+	if _, err := s.db.ExecContext(ctx, `
+		INSERT INTO musicbrainz_recording_cache (recording_mbid, response_json, expires_at, etag, last_modified)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT(recording_mbid) DO UPDATE SET
+			response_json = excluded.response_json,
+			expires_at = excluded.expires_at,
+			etag = excluded.etag,
+			last_modified = excluded.last_modified
+	`, mbid, blob, expiresAt, etag, lastModified); err != nil {
+		log.Printf("musicbrainz: failed to persist recording for mbid=%s: %v", mbid, err)
+	}
+}
+
+// dbRecordingValidators returns the ETag/Last-Modified validators stored for mbid,
+// if any, regardless of whether the cached entry has expired - an expired entry's
+// validators are still useful for a conditional revalidation request.
+func (s *MusicBrainzService) dbRecordingValidators(ctx context.Context, mbid string) (etag, lastModified string, ok bool) {
+	if s.db == nil {
+		return "", "", false
+	}
+	row := s.db.QueryRowContext(ctx, `SELECT etag, last_modified FROM musicbrainz_recording_cache WHERE recording_mbid = ?`, mbid)
+	if err := row.Scan(&etag, &lastModified); err != nil {
+		if err != sql.ErrNoRows {
+			log.Printf("musicbrainz: db recording validator lookup failed for mbid=%s: %v", mbid, err)
+		}
+		return "", "", false
+	}
+	return etag, lastModified, etag != "" || lastModified != ""
+}
+
+// dbTouchRecordingExpiry extends a cached recording's expiry after a 304 Not
+// Modified response confirms the stored body is still current, without the
+// cost of re-decoding and re-storing a response body we already have.
+func (s *MusicBrainzService) dbTouchRecordingExpiry(ctx context.Context, mbid string, ttl time.Duration) {
+	if s.db == nil {
+		return
+	}
+	expiresAt := time.Now().Add(ttl).Unix()
+	if _, err := s.db.ExecContext(ctx, `UPDATE musicbrainz_recording_cache SET expires_at = ? WHERE recording_mbid = ?`, expiresAt, mbid); err != nil {
+		log.Printf("musicbrainz: failed to extend recording cache expiry for mbid=%s: %v", mbid, err)
+	}
+}
+
+// LookupRecording fetches a single recording by MBID, checking the DB cache before
+// falling back to the MusicBrainz API. Results are cached for recordingCacheTTL,
+// which is long-lived since a recording's canonical metadata rarely changes - this
+// is what keeps HydrateTrack cheap to call repeatedly after a cold start.
+func (s *MusicBrainzService) LookupRecording(ctx context.Context, mbid string) (*MusicBrainzRecording, error) {
+	if mbid == "" {
+		return nil, fmt.Errorf("musicbrainz: recording mbid must not be empty")
+	}
+
+	if rec, ok := s.dbGetRecording(ctx, mbid); ok {
+		log.Printf("DB cache hit for MusicBrainz recording lookup: mbid=%s", mbid)
+		return rec, nil
+	}
+
+	if err := s.limiter.Wait(ctx); err != nil {
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("context cancelled during rate limiter wait: %w", ctx.Err())
+		}
+		return nil, fmt.Errorf("rate limiter error: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("https://musicbrainz.org/ws/2/recording/%s?fmt=json&inc=artists+releases+isrcs", url.QueryEscape(mbid))
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", s.userAgent)
+
+	// Carry forward any validators from a previous (possibly expired) cache
+	// entry, so an unchanged recording costs a 304 instead of a full re-fetch.
+	if etag, lastModified, ok := s.dbRecordingValidators(ctx, mbid); ok {
+		if etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+		if lastModified != "" {
+			req.Header.Set("If-Modified-Since", lastModified)
+		}
+	}
+
+	resp, err := doRequestWithRetry(ctx, s.httpClient, req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("context error during request execution: %w", ctx.Err())
+		}
+		return nil, fmt.Errorf("failed to execute request to %s: %w", endpoint, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified {
+		s.dbTouchRecordingExpiry(ctx, mbid, s.recordingCacheTTL)
+		log.Printf("MusicBrainz recording lookup not modified, extending cache for mbid=%s", mbid)
+		if rec, ok := s.dbGetRecording(ctx, mbid); ok {
+			return rec, nil
+		}
+		return nil, fmt.Errorf("received 304 Not Modified for mbid=%s but no cached body was found", mbid)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("MusicBrainz API request to %s returned status %d", endpoint, resp.StatusCode)
+	}
+
+	var rec MusicBrainzRecording
+	if err := json.NewDecoder(resp.Body).Decode(&rec); err != nil {
+		return nil, fmt.Errorf("failed to decode response from %s: %w", endpoint, err)
+	}
+
+	s.dbPutRecording(ctx, mbid, rec, s.recordingCacheTTL, resp.Header.Get("ETag"), resp.Header.Get("Last-Modified"))
+	log.Printf("Cached MusicBrainz recording lookup for mbid=%s, TTL=%s", mbid, s.recordingCacheTTL)
+
+	return &rec, nil
+}
+
+// pruneExpiredCacheEntries deletes rows from the DB cache tables whose TTL has elapsed.
+func (s *MusicBrainzService) pruneExpiredCacheEntries(ctx context.Context) {
+	now := time.Now().Unix()
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM musicbrainz_cache WHERE expires_at < ?`, now); err != nil {
+		log.Printf("musicbrainz: failed to prune search cache: %v", err)
+	}
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM musicbrainz_recording_cache WHERE expires_at < ?`, now); err != nil {
+		log.Printf("musicbrainz: failed to prune recording cache: %v", err)
+	}
+}
+
+// startCachePruner runs pruneExpiredCacheEntries on a ticker until ctx is cancelled.
+func (s *MusicBrainzService) startCachePruner(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(cachePruneInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.pruneExpiredCacheEntries(ctx)
+			}
+		}
+	}()
+}
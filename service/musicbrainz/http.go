@@ -0,0 +1,101 @@
+package musicbrainz
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// maxHTTPRetries caps retry attempts on transient failures (429/5xx/network
+	// errors). MusicBrainz and friends are rate-limited and occasionally flaky
+	// under load, but we don't want a single bad request hanging forever.
+	maxHTTPRetries = 3
+
+	// baseBackoff and maxBackoff bound the exponential backoff applied between
+	// retries, before jitter is added.
+	baseBackoff = 500 * time.Millisecond
+	maxBackoff  = 30 * time.Second
+)
+
+// doRequestWithRetry executes req, retrying on 429/5xx responses and network
+// errors with exponential backoff (honoring a Retry-After header when present).
+// req.Body must be nil or a type whose GetBody is unnecessary, since a fixed
+// *http.Request is resent as-is on every attempt - fine for the GET-only
+// requests this package makes.
+func doRequestWithRetry(ctx context.Context, client *http.Client, req *http.Request) (*http.Response, error) {
+	var lastErr error
+	for attempt := 0; attempt <= maxHTTPRetries; attempt++ {
+		resp, err := client.Do(req)
+		if err != nil {
+			lastErr = err
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			if attempt == maxHTTPRetries {
+				break
+			}
+			if !sleepBeforeRetry(ctx, exponentialBackoff(attempt)) {
+				return nil, ctx.Err()
+			}
+			continue
+		}
+
+		if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode < http.StatusInternalServerError {
+			return resp, nil
+		}
+		if attempt == maxHTTPRetries {
+			return resp, nil
+		}
+
+		wait := exponentialBackoff(attempt)
+		if ra := resp.Header.Get("Retry-After"); ra != "" {
+			wait = retryAfterDuration(ra, wait)
+		}
+		resp.Body.Close()
+		if !sleepBeforeRetry(ctx, wait) {
+			return nil, ctx.Err()
+		}
+	}
+	return nil, lastErr
+}
+
+// exponentialBackoff returns the delay before retry attempt n (0-indexed),
+// doubling each attempt up to maxBackoff and adding up to 50% jitter so
+// concurrent retries don't all land on the same tick.
+func exponentialBackoff(attempt int) time.Duration {
+	d := baseBackoff << attempt
+	if d > maxBackoff || d <= 0 {
+		d = maxBackoff
+	}
+	jitter := time.Duration(rand.Int63n(int64(d) / 2))
+	return d + jitter
+}
+
+// retryAfterDuration parses a Retry-After header, which per RFC 9110 is either
+// a number of seconds or an HTTP-date. Falls back to fallback if it's neither.
+func retryAfterDuration(header string, fallback time.Duration) time.Duration {
+	if secs, err := strconv.Atoi(header); err == nil {
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(header); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+	return fallback
+}
+
+// sleepBeforeRetry waits for d, returning false early if ctx is cancelled first.
+func sleepBeforeRetry(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
@@ -0,0 +1,62 @@
+package musicbrainz
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestExponentialBackoff(t *testing.T) {
+	tests := []struct {
+		name    string
+		attempt int
+		min     time.Duration
+		max     time.Duration
+	}{
+		{"first attempt", 0, baseBackoff, baseBackoff + baseBackoff/2},
+		{"second attempt", 1, 2 * baseBackoff, 2*baseBackoff + baseBackoff},
+		{"caps at maxBackoff", 10, maxBackoff, maxBackoff + maxBackoff/2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i < 20; i++ {
+				d := exponentialBackoff(tt.attempt)
+				if d < tt.min || d > tt.max {
+					t.Fatalf("exponentialBackoff(%d) = %v, want in [%v, %v]", tt.attempt, d, tt.min, tt.max)
+				}
+			}
+		})
+	}
+}
+
+func TestRetryAfterDuration(t *testing.T) {
+	fallback := 7 * time.Second
+
+	t.Run("seconds form", func(t *testing.T) {
+		if got := retryAfterDuration("5", fallback); got != 5*time.Second {
+			t.Errorf("retryAfterDuration(%q) = %v, want %v", "5", got, 5*time.Second)
+		}
+	})
+
+	t.Run("http-date form in the future", func(t *testing.T) {
+		future := time.Now().Add(1 * time.Hour).UTC().Format(http.TimeFormat)
+		got := retryAfterDuration(future, fallback)
+		if got <= 0 || got > time.Hour {
+			t.Errorf("retryAfterDuration(%q) = %v, want roughly 1h", future, got)
+		}
+	})
+
+	t.Run("http-date form in the past falls back", func(t *testing.T) {
+		past := time.Now().Add(-1 * time.Hour).UTC().Format(http.TimeFormat)
+		if got := retryAfterDuration(past, fallback); got != fallback {
+			t.Errorf("retryAfterDuration(%q) = %v, want fallback %v", past, got, fallback)
+		}
+	})
+
+	t.Run("unparseable falls back", func(t *testing.T) {
+		if got := retryAfterDuration("not-a-valid-header", fallback); got != fallback {
+			t.Errorf("retryAfterDuration(garbage) = %v, want fallback %v", got, fallback)
+		}
+	})
+}
@@ -15,6 +15,7 @@ import (
 
 	"github.com/teal-fm/piper/db"
 	"github.com/teal-fm/piper/models"
+	"golang.org/x/sync/singleflight"
 	"golang.org/x/time/rate"
 )
 
@@ -59,6 +60,11 @@ type SearchParams struct {
 	Track   string
 	Artist  string
 	Release string
+
+	// ISRC and DurationMs are optional context from the input track, used only to
+	// score candidates (see scoreCandidate) - they aren't part of the text query.
+	ISRC       string
+	DurationMs int64
 }
 
 // cacheEntry holds the cached data and its expiration time.
@@ -68,33 +74,115 @@ type cacheEntry struct {
 }
 
 type MusicBrainzService struct {
-	db          *db.DB
-	httpClient  *http.Client
-	limiter     *rate.Limiter
-	searchCache map[string]cacheEntry // In-memory cache for search results
-	cacheMutex  sync.RWMutex          // Mutex to protect the cache
-	cacheTTL    time.Duration         // Time-to-live for cache entries
-	cleaner     MetadataCleaner       // Cleaner for cleaning up expired cache entries
+	db                *db.DB
+	httpClient        *http.Client
+	limiter           *rate.Limiter
+	searchCache       map[string]cacheEntry // In-memory cache for search results
+	cacheMutex        sync.RWMutex          // Mutex to protect the cache
+	cacheTTL          time.Duration         // Time-to-live for in-memory/DB search cache entries
+	recordingCacheTTL time.Duration         // Time-to-live for DB-persisted recording lookups
+	cleaner           MetadataCleaner       // Normalizes track/artist names before searching and cache-key generation
+	pruneCancel       context.CancelFunc    // Stops the background cache-pruning goroutine
+	providers         []MetadataProvider    // Registered metadata sources, sorted by descending priority
+	acoustIDAPIKey    string                // API key gating the optional AcoustID provider
+	searchGroup       singleflight.Group    // Coalesces concurrent identical search requests
+	userAgent         string                // Sent on every outgoing request; MB requires a contactable UA
+}
+
+// defaultUserAgent is used when NewMusicBrainzService isn't given WithUserAgent.
+// MusicBrainz explicitly requires a contactable User-Agent and will block generic
+// ones, so callers running piper for their own service should override this.
+const defaultUserAgent = "piper/0.0.1 ( https://github.com/teal-fm/piper )"
+
+// Option configures optional parameters on a MusicBrainzService.
+type Option func(*MusicBrainzService)
+
+// WithCacheTTLs overrides the default TTLs used for the fuzzy search cache and the
+// longer-lived recording-lookup cache.
+func WithCacheTTLs(search, recording time.Duration) Option {
+	return func(s *MusicBrainzService) {
+		s.cacheTTL = search
+		s.recordingCacheTTL = recording
+	}
+}
+
+// WithAcoustIDAPIKey registers the AcoustID metadata provider using the given API key.
+// Without this option, AcoustID is left out of the provider chain entirely.
+func WithAcoustIDAPIKey(apiKey string) Option {
+	return func(s *MusicBrainzService) {
+		s.acoustIDAPIKey = apiKey
+	}
+}
+
+// WithUserAgent overrides the User-Agent sent on every outgoing request.
+// MusicBrainz (and courteous use of Cover Art Archive/ListenBrainz/AcoustID)
+// requires a contactable UA identifying the application, not a generic default.
+func WithUserAgent(userAgent string) Option {
+	return func(s *MusicBrainzService) {
+		s.userAgent = userAgent
+	}
 }
 
 // NewMusicBrainzService creates a new service instance with rate limiting and caching.
-func NewMusicBrainzService(db *db.DB) *MusicBrainzService {
+// Search results and hydrated recording lookups are persisted to db so the cache
+// survives restarts, which matters given MusicBrainz's 1 req/sec rate limit.
+func NewMusicBrainzService(db *db.DB, opts ...Option) *MusicBrainzService {
 	// MusicBrainz allows 1 request per second
 	limiter := rate.NewLimiter(rate.Every(time.Second), 1)
-	// Set a default cache TTL (e.g., 1 hour)
-	defaultCacheTTL := 1 * time.Hour
 
-	return &MusicBrainzService{
+	s := &MusicBrainzService{
 		db: db,
 		httpClient: &http.Client{
 			Timeout: 10 * time.Second,
 		},
-		limiter:     limiter,
-		searchCache: make(map[string]cacheEntry),  // Initialize the cache map
-		cacheTTL:    defaultCacheTTL,              // Set the cache TTL
-		cleaner:     *NewMetadataCleaner("Latin"), // Initialize the cleaner
+		limiter:           limiter,
+		searchCache:       make(map[string]cacheEntry), // Initialize the cache map
+		cacheTTL:          defaultSearchCacheTTL,
+		recordingCacheTTL: defaultRecordingCacheTTL,
+		cleaner:           *NewMetadataCleaner("Latin"), // Initialize the cleaner
+		userAgent:         defaultUserAgent,
 		// cacheMutex is zero-value ready
 	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	s.RegisterProvider(&musicBrainzProvider{svc: s})
+	s.RegisterProvider(newListenBrainzProvider(s.userAgent))
+	s.RegisterProvider(newCoverArtArchiveProvider(s.userAgent))
+	if s.acoustIDAPIKey != "" {
+		s.RegisterProvider(newAcoustIDProvider(s.acoustIDAPIKey, s.userAgent))
+	}
+
+	if s.db != nil {
+		ctx, cancel := context.WithCancel(context.Background())
+		s.pruneCancel = cancel
+		if err := s.ensureCacheSchema(ctx); err != nil {
+			log.Printf("musicbrainz: failed to initialize db cache schema: %v", err)
+		}
+		s.startCachePruner(ctx)
+	}
+
+	return s
+}
+
+// Close stops the background cache-pruning goroutine. Safe to call even if the
+// service was constructed without a db.
+func (s *MusicBrainzService) Close() {
+	if s.pruneCancel != nil {
+		s.pruneCancel()
+	}
+}
+
+// RegisterProvider adds a MetadataProvider to the hydration chain, keeping
+// providers sorted by descending priority. Callers can use this to plug in
+// additional sources beyond the built-in MusicBrainz/CAA/ListenBrainz/AcoustID set.
+func (s *MusicBrainzService) RegisterProvider(p MetadataProvider) {
+	s.providers = append(s.providers, p)
+	sort.SliceStable(s.providers, func(i, j int) bool {
+		return s.providers[i].Priority() > s.providers[j].Priority()
+	})
 }
 
 // generateCacheKey creates a unique string key for caching based on search parameters.
@@ -107,6 +195,17 @@ func generateCacheKey(params SearchParams) string {
 		url.QueryEscape(params.Release))
 }
 
+// searchCacheKeyFor computes the cache key SearchMusicBrainz will use for track,
+// after applying the same MetadataCleaner normalization it does internally. Used
+// by HydrateTracks to group duplicate queries and to check cache state without
+// triggering a network call.
+func (s *MusicBrainzService) searchCacheKeyFor(track models.Track) string {
+	params := searchParamsFor(track)
+	params.Track, _ = s.cleaner.CleanRecording(params.Track)
+	params.Artist, _ = s.cleaner.CleanArtist(params.Artist)
+	return generateCacheKey(params)
+}
+
 // SearchMusicBrainz searches the MusicBrainz API for recordings, using an in-memory cache.
 func (s *MusicBrainzService) SearchMusicBrainz(ctx context.Context, params SearchParams) ([]MusicBrainzRecording, error) {
 	// Validate parameters first
@@ -131,170 +230,283 @@ func (s *MusicBrainzService) SearchMusicBrainz(ctx context.Context, params Searc
 		// Return the cached data directly. Consider if a deep copy is needed if callers modify results.
 		return entry.recordings, nil
 	}
-	// --- Cache Miss or Expired ---
+	// --- Cache Miss or Expired (in-memory) ---
 	if found {
 		log.Printf("Cache expired for MusicBrainz search: key=%s", cacheKey)
 	} else {
 		log.Printf("Cache miss for MusicBrainz search: key=%s", cacheKey)
 	}
 
-	// --- Proceed with API call ---
-	queryParts := []string{}
-	if params.Track != "" {
-		queryParts = append(queryParts, fmt.Sprintf(`recording:"%s"`, params.Track))
-	}
-	if params.Artist != "" {
-		queryParts = append(queryParts, fmt.Sprintf(`artist:"%s"`, params.Artist))
+	// --- Fall back to the DB-persisted cache before hitting the network ---
+	if recordings, ok := s.dbGetSearch(ctx, cacheKey); ok {
+		log.Printf("DB cache hit for MusicBrainz search: key=%s", cacheKey)
+		s.cacheMutex.Lock()
+		s.searchCache[cacheKey] = cacheEntry{recordings: recordings, expiresAt: now.Add(s.cacheTTL)}
+		s.cacheMutex.Unlock()
+		return recordings, nil
 	}
-	if params.Release != "" {
-		queryParts = append(queryParts, fmt.Sprintf(`release:"%s"`, params.Release))
-	}
-	query := strings.Join(queryParts, " AND ")
-	endpoint := fmt.Sprintf("https://musicbrainz.org/ws/2/recording?query=%s&fmt=json&inc=artists+releases+isrcs", url.QueryEscape(query))
 
-	if err := s.limiter.Wait(ctx); err != nil {
-		if ctx.Err() != nil {
-			return nil, fmt.Errorf("context cancelled during rate limiter wait: %w", ctx.Err())
+	// --- Proceed with API call, coalescing concurrent identical requests so 50
+	// callers asking for the same track produce exactly one HTTP call. ---
+	v, err, _ := s.searchGroup.Do(cacheKey, func() (interface{}, error) {
+		queryParts := []string{}
+		if params.Track != "" {
+			queryParts = append(queryParts, fmt.Sprintf(`recording:"%s"`, params.Track))
+		}
+		if params.Artist != "" {
+			queryParts = append(queryParts, fmt.Sprintf(`artist:"%s"`, params.Artist))
+		}
+		if params.Release != "" {
+			queryParts = append(queryParts, fmt.Sprintf(`release:"%s"`, params.Release))
+		}
+		query := strings.Join(queryParts, " AND ")
+		endpoint := fmt.Sprintf("https://musicbrainz.org/ws/2/recording?query=%s&fmt=json&inc=artists+releases+isrcs", url.QueryEscape(query))
+
+		if err := s.limiter.Wait(ctx); err != nil {
+			if ctx.Err() != nil {
+				return nil, fmt.Errorf("context cancelled during rate limiter wait: %w", ctx.Err())
+			}
+			return nil, fmt.Errorf("rate limiter error: %w", err)
 		}
-		return nil, fmt.Errorf("rate limiter error: %w", err)
-	}
 
-	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-	req.Header.Set("User-Agent", "piper/0.0.1 ( https://github.com/teal-fm/piper )")
+		req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+		req.Header.Set("User-Agent", s.userAgent)
+
+		resp, err := doRequestWithRetry(ctx, s.httpClient, req)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, fmt.Errorf("context error during request execution: %w", ctx.Err())
+			}
+			return nil, fmt.Errorf("failed to execute request to %s: %w", endpoint, err)
+		}
+		defer resp.Body.Close()
 
-	resp, err := s.httpClient.Do(req)
-	if err != nil {
-		if ctx.Err() != nil {
-			return nil, fmt.Errorf("context error during request execution: %w", ctx.Err())
+		if resp.StatusCode != http.StatusOK {
+			// TODO: Consider reading body for detailed error message from MusicBrainz
+			return nil, fmt.Errorf("MusicBrainz API request to %s returned status %d", endpoint, resp.StatusCode)
 		}
-		return nil, fmt.Errorf("failed to execute request to %s: %w", endpoint, err)
-	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		// TODO: Consider reading body for detailed error message from MusicBrainz
-		return nil, fmt.Errorf("MusicBrainz API request to %s returned status %d", endpoint, resp.StatusCode)
-	}
+		var result MusicBrainzSearchResponse
+		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+			return nil, fmt.Errorf("failed to decode response from %s: %w", endpoint, err)
+		}
 
-	var result MusicBrainzSearchResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("failed to decode response from %s: %w", endpoint, err)
-	}
+		// cache result for later, both in-memory and on disk
+		s.cacheMutex.Lock()
+		s.searchCache[cacheKey] = cacheEntry{
+			recordings: result.Recordings,
+			expiresAt:  time.Now().Add(s.cacheTTL),
+		}
+		s.cacheMutex.Unlock()
+		s.dbPutSearch(ctx, cacheKey, result.Recordings, s.cacheTTL)
+		log.Printf("Cached MusicBrainz search result for key=%s, TTL=%s", cacheKey, s.cacheTTL)
 
-	// cache result for later
-	s.cacheMutex.Lock()
-	s.searchCache[cacheKey] = cacheEntry{
-		recordings: result.Recordings,
-		expiresAt:  time.Now().Add(s.cacheTTL),
+		return result.Recordings, nil
+	})
+	if err != nil {
+		return nil, err
 	}
-	s.cacheMutex.Unlock()
-	log.Printf("Cached MusicBrainz search result for key=%s, TTL=%s", cacheKey, s.cacheTTL)
 
 	// Return the newly fetched results
-	return result.Recordings, nil
+	return v.([]MusicBrainzRecording), nil
 }
 
-// GetBestRelease selects the 'best' release from a list based on specific criteria.
+// GetBestRelease selects the 'best' release from a list. Official releases are
+// filtered to first - an additive bonus can't be trusted to outrank "Official"
+// reliably, since a self-titled official release and a non-official release
+// that happens to win both the region and title bonuses would otherwise tie or
+// even flip, letting a bootleg/promo outrank the official release - so this
+// stays a hard gate. Within that official/non-official group, releases are then
+// ranked by releaseScore (region and self-title signals). scoreCandidate applies
+// its non-"Official" penalty to the release chosen here, so track scoring and
+// release selection agree on which release is best.
 func GetBestRelease(releases []MusicBrainzRelease, trackTitle string) *MusicBrainzRelease {
 	if len(releases) == 0 {
 		return nil
 	}
-	if len(releases) == 1 {
+
+	candidates := preferOfficialReleases(releases)
+
+	if len(candidates) == 1 {
 		// Return a pointer to the single element
-		r := releases[0]
+		r := candidates[0]
 		return &r
 	}
 
-	// Sort releases: Prefer valid dates first, then sort by date, title, id.
-	sort.SliceStable(releases, func(i, j int) bool {
-		dateA := releases[i].Date
-		dateB := releases[j].Date
+	// Sort by releaseScore descending; among ties, prefer valid dates, then the
+	// oldest date, then title, then id, same tie-break order as before.
+	sort.SliceStable(candidates, func(i, j int) bool {
+		scoreA := releaseScore(candidates[i], trackTitle)
+		scoreB := releaseScore(candidates[j], trackTitle)
+		if scoreA != scoreB {
+			return scoreA > scoreB
+		}
+
+		dateA := candidates[i].Date
+		dateB := candidates[j].Date
 		validDateA := len(dateA) >= 4 // Basic check for YYYY format or longer
 		validDateB := len(dateB) >= 4
 
 		// Put invalid/empty dates at the end
-		if validDateA && !validDateB {
-			return true
-		}
-		if !validDateA && validDateB {
-			return false
+		if validDateA != validDateB {
+			return validDateA
 		}
 		// If both valid or both invalid, compare dates lexicographically
 		if dateA != dateB {
 			return dateA < dateB
 		}
 		// If dates are same, compare by title
-		if releases[i].Title != releases[j].Title {
-			return releases[i].Title < releases[j].Title
+		if candidates[i].Title != candidates[j].Title {
+			return candidates[i].Title < candidates[j].Title
 		}
 		// If titles are same, compare by ID
-		return releases[i].ID < releases[j].ID
+		return candidates[i].ID < candidates[j].ID
 	})
 
-	// 1. Find oldest release where country is 'XW' or 'US' AND title is NOT track title
-	for i := range releases {
-		release := &releases[i]
-		if (release.Country == "XW" || release.Country == "US") && release.Title != trackTitle {
-			return release
-		}
-	}
+	r := candidates[0]
+	return &r
+}
 
-	// 2. If none, find oldest release where title is NOT track title
-	for i := range releases {
-		release := &releases[i]
-		if release.Title != trackTitle {
-			return release
+// preferOfficialReleases filters to releases with Status "Official" (or unset,
+// since older MB data often omits it) when at least one exists, otherwise returns
+// releases unfiltered so a bootleg-only recording still resolves to something.
+// This is a hard gate rather than folded into releaseScore's additive bonuses,
+// since region/title bonuses could otherwise let a non-official release outscore
+// an official one on secondary signals alone.
+func preferOfficialReleases(releases []MusicBrainzRelease) []MusicBrainzRelease {
+	official := make([]MusicBrainzRelease, 0, len(releases))
+	for _, r := range releases {
+		if r.Status == "" || r.Status == "Official" {
+			official = append(official, r)
 		}
 	}
+	if len(official) > 0 {
+		return official
+	}
+	return releases
+}
 
-	// 3. If none found, return the oldest release overall (which is the first one after sorting)
-	log.Printf("Could not find a suitable release for '%s', picking oldest: '%s' (%s)", trackTitle, releases[0].Title, releases[0].ID)
-	r := releases[0]
-	return &r
+// HydrateTrack enriches track with metadata gathered from every registered
+// MetadataProvider (MusicBrainz, Cover Art Archive, ListenBrainz, and optionally
+// AcoustID). Candidates are merged by recording MBID/ISRC and fields are filled in
+// provider-priority order, so e.g. cover art comes from Cover Art Archive while
+// canonical recording/release IDs come from MusicBrainz.
+func (mb *MusicBrainzService) HydrateTrack(ctx context.Context, track models.Track) (*models.Track, error) {
+	return mb.HydrateTrackWithOptions(ctx, track, HydrateOptions{})
 }
 
-func HydrateTrack(mb *MusicBrainzService, track models.Track) (*models.Track, error) {
-	ctx := context.Background()
-	// array of strings
+// HydrateOptions configures how choosy HydrateTrackWithOptions is about the
+// candidate it picks.
+type HydrateOptions struct {
+	// MinScore rejects weak matches outright: if the best candidate (after the
+	// AcoustID fallback, if any) scores below this, HydrateTrackWithOptions
+	// returns an *ErrLowConfidence instead of guessing. Zero means accept anything.
+	MinScore float64
+}
+
+// ErrLowConfidence is returned by HydrateTrackWithOptions when the best available
+// candidate didn't clear opts.MinScore. Callers can inspect Best/Score to decide
+// whether to use it anyway, ask for confirmation, or give up.
+type ErrLowConfidence struct {
+	Best  Candidate
+	Score float64
+}
+
+func (e *ErrLowConfidence) Error() string {
+	return fmt.Sprintf("musicbrainz: best match %q scored %.3f, below required minimum", e.Best.Title, e.Score)
+}
+
+// fingerprintFallbackScoreThreshold is how low scoreMergedCandidate can go before
+// HydrateTrackWithOptions tries an AcoustID fingerprint lookup (when available)
+// rather than trusting the name-search result.
+const fingerprintFallbackScoreThreshold = 0.55
+
+// HydrateTrackWithOptions enriches track with metadata gathered from every
+// registered MetadataProvider (MusicBrainz, Cover Art Archive, ListenBrainz, and
+// optionally AcoustID). Candidates are merged by recording MBID/ISRC, scored
+// against the input with scoreMergedCandidate, and the highest-scoring candidate
+// wins - no more blindly trusting whichever provider returned its result first.
+func (mb *MusicBrainzService) HydrateTrackWithOptions(ctx context.Context, track models.Track, opts HydrateOptions) (*models.Track, error) {
+	result, _, _, err := mb.hydrate(ctx, track, opts)
+	return result, err
+}
+
+// searchParamsFor builds the SearchParams providers search against for a track,
+// including the optional scoring context (ISRC, duration).
+func searchParamsFor(track models.Track) SearchParams {
 	artistArray := make([]string, len(track.Artist)) // Assuming Name is string type
 	for i, a := range track.Artist {
 		artistArray[i] = a.Name
 	}
 
-	params := SearchParams{
-		Track:   track.Name,
-		Artist:  strings.Join(artistArray, ", "),
-		Release: track.Album,
+	durationMs := track.DurationMs
+	if durationMs == 0 {
+		durationMs = track.ProgressMs
 	}
-	res, err := mb.SearchMusicBrainz(ctx, params)
-	if err != nil {
-		return nil, err
+
+	return SearchParams{
+		Track:      track.Name,
+		Artist:     strings.Join(artistArray, ", "),
+		Release:    track.Album,
+		ISRC:       track.ISRC,
+		DurationMs: durationMs,
+	}
+}
+
+// hydrate is the shared implementation behind HydrateTrackWithOptions and
+// HydrateTracks. Besides the hydrated track it also reports the winning
+// candidate's score and which path produced it, which HydrateTracks needs to
+// populate HydrateResult.Score/Source.
+func (mb *MusicBrainzService) hydrate(ctx context.Context, track models.Track, opts HydrateOptions) (*models.Track, float64, HydrateSource, error) {
+	params := searchParamsFor(track)
+
+	var candidates []Candidate
+	for _, p := range mb.providers {
+		found, err := p.Search(ctx, params)
+		if err != nil {
+			log.Printf("musicbrainz: provider %s search failed: %v", p.Name(), err)
+			continue
+		}
+		candidates = append(candidates, found...)
 	}
 
-	if len(res) == 0 {
-		return nil, errors.New("no results found")
+	best, bestScore, haveCandidate := mb.pickBestCandidate(params, mergeCandidates(candidates))
+	source := HydrateSourceNetwork
+
+	// Name-based search can return a confidently-wrong top hit (e.g. garbage
+	// titles from stream/scrobble sources); fall back to an AcoustID fingerprint
+	// lookup when we have one to try and the top hit looks unreliable.
+	lowConfidence := !haveCandidate || bestScore < fingerprintFallbackScoreThreshold
+	if lowConfidence && track.Fingerprint != "" && mb.acoustIDAPIKey != "" {
+		if fpCandidate, err := mb.resolveFingerprint(ctx, track.Fingerprint, int(params.DurationMs/1000)); err != nil {
+			log.Printf("musicbrainz: fingerprint fallback failed: %v", err)
+		} else if fpCandidate != nil {
+			if fpScore := mb.scoreMergedCandidate(params, *fpCandidate); !haveCandidate || fpScore > bestScore {
+				best, bestScore, haveCandidate = *fpCandidate, fpScore, true
+				source = HydrateSourceFingerprint
+			}
+		}
 	}
 
-	firstResult := res[0]
-	firstResultAlbum := GetBestRelease(firstResult.Releases, firstResult.Title)
+	if !haveCandidate {
+		return nil, 0, source, errors.New("no results found")
+	}
 
-	bestISRC := firstResult.ISRCs[0]
+	if opts.MinScore > 0 && bestScore < opts.MinScore {
+		return nil, bestScore, source, &ErrLowConfidence{Best: best, Score: bestScore}
+	}
 
-	if len(firstResult.ISRCs) == 0 {
+	bestISRC := best.ISRC
+	if bestISRC == "" {
 		bestISRC = track.ISRC
 	}
 
-	artists := make([]models.Artist, len(firstResult.ArtistCredit))
-
-	for i, a := range firstResult.ArtistCredit {
-		artists[i] = models.Artist{
-			Name: a.Name,
-			ID:   a.Artist.ID,
-			MBID: a.Artist.ID,
-		}
+	artists := best.Artists
+	if len(artists) == 0 {
+		artists = track.Artist
 	}
 
 	resTrack := models.Track{
@@ -303,15 +515,30 @@ func HydrateTrack(mb *MusicBrainzService, track models.Track) (*models.Track, er
 		Name:           track.Name,
 		URL:            track.URL,
 		ServiceBaseUrl: track.ServiceBaseUrl,
-		RecordingMBID:  firstResult.ID,
-		Album:          firstResultAlbum.Title,
-		ReleaseMBID:    firstResultAlbum.ID,
+		RecordingMBID:  best.RecordingMBID,
+		Album:          best.Album,
+		ReleaseMBID:    best.ReleaseMBID,
 		ISRC:           bestISRC,
 		Timestamp:      track.Timestamp,
 		ProgressMs:     track.ProgressMs,
-		DurationMs:     int64(firstResult.Length),
+		DurationMs:     best.DurationMs,
 		Artist:         artists,
 	}
 
-	return &resTrack, nil
+	for _, p := range mb.providers {
+		if err := p.Enrich(ctx, &resTrack); err != nil {
+			log.Printf("musicbrainz: provider %s enrich failed: %v", p.Name(), err)
+		}
+	}
+
+	return &resTrack, bestScore, source, nil
+}
+
+// HydrateTrack is a convenience wrapper around (*MusicBrainzService).HydrateTrack
+// for existing callers that don't carry a context.
+//
+// Deprecated: call (*MusicBrainzService).HydrateTrack directly so callers can
+// supply their own context.
+func HydrateTrack(mb *MusicBrainzService, track models.Track) (*models.Track, error) {
+	return mb.HydrateTrack(context.Background(), track)
 }
@@ -0,0 +1,396 @@
+package musicbrainz
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/teal-fm/piper/models"
+)
+
+// MetadataProvider is a single metadata source that can be plugged into
+// MusicBrainzService's hydration pipeline. Priority determines which provider's
+// fields win when candidates from multiple providers are merged - higher wins.
+type MetadataProvider interface {
+	Name() string
+	Priority() int
+	Search(ctx context.Context, params SearchParams) ([]Candidate, error)
+	Enrich(ctx context.Context, track *models.Track) error
+}
+
+// Candidate is a provider's guess at a track's identity, normalized enough that
+// candidates from different providers can be merged by RecordingMBID/ISRC.
+//
+// CoverArtURL mirrors models.Track.CoverArtURL, and Fingerprint elsewhere in this
+// package mirrors models.Track.Fingerprint. Unlike the rest of models.Track used
+// in this package (Name, Artist, Album, ISRC, etc.), which predate this series,
+// CoverArtURL and Fingerprint are new usages this series introduces - the models
+// package itself lives outside service/musicbrainz and needs its own change
+// adding these two fields; that change isn't part of this package's commits and
+// must land alongside it for the package to build.
+type Candidate struct {
+	Provider      string
+	Priority      int
+	RecordingMBID string
+	ReleaseMBID   string
+	Title         string
+	Album         string
+	Artists       []models.Artist
+	ISRC          string
+	DurationMs    int64
+	CoverArtURL   string
+
+	// mbRecording carries the raw MusicBrainz payload through to scoring, when
+	// available, so scoreMergedCandidate can apply the fuller scoreCandidate
+	// signal set (e.g. the non-"Official" release penalty) instead of the
+	// generic fallback.
+	mbRecording *MusicBrainzRecording
+}
+
+// mergeCandidates groups candidates that refer to the same recording (matched by
+// MBID, falling back to ISRC) and fills each group's fields from whichever
+// provider offered them, preferring higher-priority providers when more than one
+// populates the same field. Order is preserved from first occurrence, so the
+// group built from the first (and usually highest-priority) candidate comes first.
+func mergeCandidates(candidates []Candidate) []Candidate {
+	groups := make(map[string]*Candidate, len(candidates))
+	order := make([]string, 0, len(candidates))
+
+	for _, c := range candidates {
+		key := candidateKey(c)
+		if existing, ok := groups[key]; ok {
+			mergeCandidateInto(existing, c)
+			continue
+		}
+		cc := c
+		groups[key] = &cc
+		order = append(order, key)
+	}
+
+	merged := make([]Candidate, 0, len(order))
+	for _, key := range order {
+		merged = append(merged, *groups[key])
+	}
+	return merged
+}
+
+func candidateKey(c Candidate) string {
+	if c.RecordingMBID != "" {
+		return "mbid:" + c.RecordingMBID
+	}
+	if c.ISRC != "" {
+		return "isrc:" + c.ISRC
+	}
+	return "anon:" + c.Provider + ":" + c.Title
+}
+
+// mergeCandidateInto folds src into dst, keeping dst's fields where src is lower
+// priority and only filling gaps, or adopting src's fields (filling its gaps from
+// dst) when src outranks dst.
+func mergeCandidateInto(dst *Candidate, src Candidate) {
+	if src.Priority <= dst.Priority {
+		fillEmptyCandidateFields(dst, src)
+		return
+	}
+	winner := src
+	fillEmptyCandidateFields(&winner, *dst)
+	*dst = winner
+}
+
+func fillEmptyCandidateFields(dst *Candidate, src Candidate) {
+	if dst.RecordingMBID == "" {
+		dst.RecordingMBID = src.RecordingMBID
+	}
+	if dst.ReleaseMBID == "" {
+		dst.ReleaseMBID = src.ReleaseMBID
+	}
+	if dst.Title == "" {
+		dst.Title = src.Title
+	}
+	if dst.Album == "" {
+		dst.Album = src.Album
+	}
+	if len(dst.Artists) == 0 {
+		dst.Artists = src.Artists
+	}
+	if dst.ISRC == "" {
+		dst.ISRC = src.ISRC
+	}
+	if dst.DurationMs == 0 {
+		dst.DurationMs = src.DurationMs
+	}
+	if dst.CoverArtURL == "" {
+		dst.CoverArtURL = src.CoverArtURL
+	}
+	if dst.mbRecording == nil {
+		dst.mbRecording = src.mbRecording
+	}
+}
+
+// musicBrainzProvider adapts the existing MusicBrainz client to MetadataProvider.
+// It ranks highest since its recording/release MBIDs are what every other
+// provider's lookups are keyed on.
+type musicBrainzProvider struct {
+	svc *MusicBrainzService
+}
+
+func (p *musicBrainzProvider) Name() string  { return "musicbrainz" }
+func (p *musicBrainzProvider) Priority() int { return 100 }
+
+func (p *musicBrainzProvider) Search(ctx context.Context, params SearchParams) ([]Candidate, error) {
+	recordings, err := p.svc.SearchMusicBrainz(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	// The search endpoint's embedded recording is sometimes thinner than a direct
+	// lookup (e.g. missing ISRCs); LookupRecording is backed by a long-lived cache
+	// so thickening just the top hit is cheap after the first hit for a given
+	// recording, and that's the only candidate scoring is likely to pick anyway.
+	if len(recordings) > 0 {
+		if full, err := p.svc.LookupRecording(ctx, recordings[0].ID); err == nil && full != nil {
+			recordings[0] = *full
+		}
+	}
+
+	candidates := make([]Candidate, len(recordings))
+	for i, rec := range recordings {
+		candidates[i] = recordingToCandidate(p.Name(), p.Priority(), rec)
+	}
+	return candidates, nil
+}
+
+func (p *musicBrainzProvider) Enrich(ctx context.Context, track *models.Track) error {
+	// Recording/release data is already filled in from Search; nothing further to add.
+	return nil
+}
+
+func recordingToCandidate(provider string, priority int, rec MusicBrainzRecording) Candidate {
+	best := GetBestRelease(rec.Releases, rec.Title)
+
+	var album, releaseMBID string
+	if best != nil {
+		album = best.Title
+		releaseMBID = best.ID
+	}
+
+	var isrc string
+	if len(rec.ISRCs) > 0 {
+		isrc = rec.ISRCs[0]
+	}
+
+	artists := make([]models.Artist, len(rec.ArtistCredit))
+	for i, a := range rec.ArtistCredit {
+		artists[i] = models.Artist{
+			Name: a.Name,
+			ID:   a.Artist.ID,
+			MBID: a.Artist.ID,
+		}
+	}
+
+	return Candidate{
+		Provider:      provider,
+		Priority:      priority,
+		RecordingMBID: rec.ID,
+		ReleaseMBID:   releaseMBID,
+		Title:         rec.Title,
+		Album:         album,
+		Artists:       artists,
+		ISRC:          isrc,
+		DurationMs:    int64(rec.Length),
+		mbRecording:   &rec,
+	}
+}
+
+// coverArtArchiveProvider fetches release artwork from coverartarchive.org. It has
+// no search of its own - it only enriches a track once some other provider has
+// resolved a ReleaseMBID - so it ranks low enough to never win an identity field.
+type coverArtArchiveProvider struct {
+	httpClient *http.Client
+	userAgent  string
+}
+
+func newCoverArtArchiveProvider(userAgent string) *coverArtArchiveProvider {
+	return &coverArtArchiveProvider{
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		userAgent:  userAgent,
+	}
+}
+
+func (p *coverArtArchiveProvider) Name() string  { return "coverartarchive" }
+func (p *coverArtArchiveProvider) Priority() int { return 10 }
+
+func (p *coverArtArchiveProvider) Search(ctx context.Context, params SearchParams) ([]Candidate, error) {
+	return nil, nil
+}
+
+type coverArtArchiveResponse struct {
+	Images []struct {
+		Front bool   `json:"front"`
+		Image string `json:"image"`
+	} `json:"images"`
+}
+
+func (p *coverArtArchiveProvider) Enrich(ctx context.Context, track *models.Track) error {
+	if track.ReleaseMBID == "" {
+		return nil
+	}
+
+	endpoint := fmt.Sprintf("https://coverartarchive.org/release/%s", url.PathEscape(track.ReleaseMBID))
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("coverartarchive: failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", p.userAgent)
+
+	resp, err := doRequestWithRetry(ctx, p.httpClient, req)
+	if err != nil {
+		return fmt.Errorf("coverartarchive: request to %s failed: %w", endpoint, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil // no artwork for this release; not an error
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("coverartarchive: request to %s returned status %d", endpoint, resp.StatusCode)
+	}
+
+	var body coverArtArchiveResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return fmt.Errorf("coverartarchive: failed to decode response from %s: %w", endpoint, err)
+	}
+
+	for _, img := range body.Images {
+		if img.Front {
+			track.CoverArtURL = img.Image
+			return nil
+		}
+	}
+	if len(body.Images) > 0 {
+		track.CoverArtURL = body.Images[0].Image
+	}
+	return nil
+}
+
+// listenBrainzProvider resolves tracks via the ListenBrainz metadata lookup
+// API, which has a much more generous rate limit than MusicBrainz itself. Its
+// Enrich adds nothing.
+type listenBrainzProvider struct {
+	httpClient *http.Client
+	userAgent  string
+}
+
+func newListenBrainzProvider(userAgent string) *listenBrainzProvider {
+	return &listenBrainzProvider{
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		userAgent:  userAgent,
+	}
+}
+
+func (p *listenBrainzProvider) Name() string  { return "listenbrainz" }
+func (p *listenBrainzProvider) Priority() int { return 75 }
+
+type listenBrainzLookupResult struct {
+	RecordingMBID string `json:"recording_mbid"`
+	ReleaseMBID   string `json:"release_mbid"`
+	RecordingName string `json:"recording_name"`
+	ReleaseName   string `json:"release_name"`
+}
+
+func (p *listenBrainzProvider) Search(ctx context.Context, params SearchParams) ([]Candidate, error) {
+	if params.Track == "" || params.Artist == "" {
+		return nil, nil
+	}
+
+	q := url.Values{}
+	q.Set("recording_name", params.Track)
+	q.Set("artist_name", params.Artist)
+	if params.Release != "" {
+		q.Set("release_name", params.Release)
+	}
+	endpoint := "https://api.listenbrainz.org/1/metadata/lookup/?" + q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("listenbrainz: failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", p.userAgent)
+
+	resp, err := doRequestWithRetry(ctx, p.httpClient, req)
+	if err != nil {
+		return nil, fmt.Errorf("listenbrainz: request to %s failed: %w", endpoint, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("listenbrainz: request to %s returned status %d", endpoint, resp.StatusCode)
+	}
+
+	var result listenBrainzLookupResult
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("listenbrainz: failed to decode response from %s: %w", endpoint, err)
+	}
+	if result.RecordingMBID == "" {
+		return nil, nil
+	}
+
+	return []Candidate{{
+		Provider:      p.Name(),
+		Priority:      p.Priority(),
+		RecordingMBID: result.RecordingMBID,
+		ReleaseMBID:   result.ReleaseMBID,
+		Title:         result.RecordingName,
+		Album:         result.ReleaseName,
+	}}, nil
+}
+
+// Enrich is a no-op: the recording already carries everything ListenBrainz's
+// own Search contributed, and models.Track doesn't yet model the richer
+// tag/genre data the /1/metadata/recording/ endpoint returns, so there's
+// nothing worth an extra round trip for here.
+func (p *listenBrainzProvider) Enrich(ctx context.Context, track *models.Track) error {
+	return nil
+}
+
+// acoustIDProvider is gated on an API key and ranks below the name-search
+// providers (MusicBrainz, ListenBrainz), since it's a last-resort fallback for
+// tracks those providers can't resolve by name. Text
+// search isn't meaningful for AcoustID - see HydrateTrackByFingerprint for the
+// actual fingerprint-based lookup path.
+type acoustIDProvider struct {
+	apiKey     string
+	httpClient *http.Client
+	userAgent  string
+}
+
+func newAcoustIDProvider(apiKey, userAgent string) *acoustIDProvider {
+	return &acoustIDProvider{
+		apiKey:     apiKey,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		userAgent:  userAgent,
+	}
+}
+
+// acoustIDProviderName and acoustIDProviderPriority are the constant values
+// behind acoustIDProvider's Name()/Priority(); resolveFingerprint in acoustid.go
+// needs them to build a Candidate without a provider instance on hand, so they're
+// consts rather than only living on the struct's methods.
+const (
+	acoustIDProviderName     = "acoustid"
+	acoustIDProviderPriority = 25
+)
+
+func (p *acoustIDProvider) Name() string  { return acoustIDProviderName }
+func (p *acoustIDProvider) Priority() int { return acoustIDProviderPriority }
+
+func (p *acoustIDProvider) Search(ctx context.Context, params SearchParams) ([]Candidate, error) {
+	return nil, nil
+}
+
+func (p *acoustIDProvider) Enrich(ctx context.Context, track *models.Track) error {
+	return nil
+}
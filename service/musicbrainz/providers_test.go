@@ -0,0 +1,76 @@
+package musicbrainz
+
+import "testing"
+
+func TestMergeCandidatesGroupsByMBID(t *testing.T) {
+	candidates := []Candidate{
+		{Provider: "musicbrainz", Priority: 100, RecordingMBID: "mbid-1", Title: "Song A", ISRC: "ISRC1"},
+		{Provider: "listenbrainz", Priority: 75, RecordingMBID: "mbid-1", Album: "Album A"},
+		{Provider: "musicbrainz", Priority: 100, RecordingMBID: "mbid-2", Title: "Song B"},
+	}
+
+	merged := mergeCandidates(candidates)
+	if len(merged) != 2 {
+		t.Fatalf("expected 2 merged candidates, got %d", len(merged))
+	}
+
+	first := merged[0]
+	if first.RecordingMBID != "mbid-1" || first.Title != "Song A" || first.Album != "Album A" {
+		t.Errorf("unexpected merge result for mbid-1: %+v", first)
+	}
+}
+
+func TestMergeCandidatesFallsBackToISRC(t *testing.T) {
+	candidates := []Candidate{
+		{Provider: "a", Priority: 50, ISRC: "ISRC1", Title: "Song"},
+		{Provider: "b", Priority: 10, ISRC: "ISRC1", Album: "From B"},
+	}
+
+	merged := mergeCandidates(candidates)
+	if len(merged) != 1 {
+		t.Fatalf("expected candidates sharing an ISRC to merge into 1, got %d", len(merged))
+	}
+	if merged[0].Album != "From B" {
+		t.Errorf("expected gap-filled Album from lower-priority candidate, got %q", merged[0].Album)
+	}
+}
+
+func TestMergeCandidatesAnonymousCandidatesStaySeparate(t *testing.T) {
+	candidates := []Candidate{
+		{Provider: "a", Title: "Song One"},
+		{Provider: "a", Title: "Song Two"},
+	}
+
+	merged := mergeCandidates(candidates)
+	if len(merged) != 2 {
+		t.Fatalf("expected candidates with no MBID/ISRC and different titles to stay separate, got %d", len(merged))
+	}
+}
+
+func TestMergeCandidateIntoHigherPriorityWins(t *testing.T) {
+	dst := Candidate{Provider: "listenbrainz", Priority: 75, RecordingMBID: "mbid-1", Title: "LB Title"}
+	src := Candidate{Provider: "musicbrainz", Priority: 100, RecordingMBID: "mbid-1", Title: "MB Title", ISRC: "ISRC1"}
+
+	mergeCandidateInto(&dst, src)
+
+	if dst.Title != "MB Title" {
+		t.Errorf("expected higher-priority src's Title to win, got %q", dst.Title)
+	}
+	if dst.ISRC != "ISRC1" {
+		t.Errorf("expected ISRC filled in from winning src, got %q", dst.ISRC)
+	}
+}
+
+func TestMergeCandidateIntoLowerPriorityOnlyFillsGaps(t *testing.T) {
+	dst := Candidate{Provider: "musicbrainz", Priority: 100, RecordingMBID: "mbid-1", Title: "MB Title"}
+	src := Candidate{Provider: "listenbrainz", Priority: 75, RecordingMBID: "mbid-1", Title: "LB Title", Album: "LB Album"}
+
+	mergeCandidateInto(&dst, src)
+
+	if dst.Title != "MB Title" {
+		t.Errorf("expected dst's existing Title to survive a lower-priority merge, got %q", dst.Title)
+	}
+	if dst.Album != "LB Album" {
+		t.Errorf("expected dst's empty Album to be filled from src, got %q", dst.Album)
+	}
+}
@@ -0,0 +1,191 @@
+package musicbrainz
+
+import (
+	"math"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/teal-fm/piper/models"
+)
+
+const (
+	titleWeight         = 0.40
+	artistWeight        = 0.30
+	durationWeight      = 0.15
+	isrcBonus           = 0.20
+	unofficialPenalty   = 0.15
+	durationToleranceMs = 15000 // full duration credit fades to zero by this much drift
+
+	// releaseRegionBonus and releaseNotSelfTitledBonus feed releaseScore, the
+	// secondary ranking GetBestRelease applies within an already
+	// official/non-official group (see preferOfficialReleases).
+	releaseRegionBonus        = 0.10
+	releaseNotSelfTitledBonus = 0.10
+)
+
+// pickBestCandidate scores every merged candidate against params and returns the
+// highest-scoring one.
+func (mb *MusicBrainzService) pickBestCandidate(params SearchParams, merged []Candidate) (Candidate, float64, bool) {
+	var best Candidate
+	bestScore := math.Inf(-1)
+	found := false
+	for _, c := range merged {
+		score := mb.scoreMergedCandidate(params, c)
+		if !found || score > bestScore {
+			best, bestScore, found = c, score, true
+		}
+	}
+	return best, bestScore, found
+}
+
+// scoreCandidate combines several weak signals into a single confidence score for
+// how well rec matches the original search input: normalized title similarity
+// (after MetadataCleaner), artist token-set overlap, duration closeness, an ISRC
+// exact-match bonus, and a penalty if the recording's best release isn't
+// "Official" (reissues/comps/bootlegs otherwise skew MB's relevance ranking more
+// than they should).
+func (mb *MusicBrainzService) scoreCandidate(input SearchParams, rec MusicBrainzRecording) float64 {
+	cleanTitle, _ := mb.cleaner.CleanRecording(input.Track)
+	score := titleWeight * normalizedSimilarity(strings.ToLower(cleanTitle), strings.ToLower(rec.Title))
+
+	cleanArtist, _ := mb.cleaner.CleanArtist(input.Artist)
+	score += artistWeight * tokenSetRatio(cleanArtist, joinArtistCredit(rec.ArtistCredit))
+
+	if sim, ok := durationSimilarity(input.DurationMs, int64(rec.Length)); ok {
+		score += durationWeight * sim
+	}
+
+	if input.ISRC != "" {
+		for _, isrc := range rec.ISRCs {
+			if isrc == input.ISRC {
+				score += isrcBonus
+				break
+			}
+		}
+	}
+
+	if best := GetBestRelease(rec.Releases, rec.Title); best != nil && best.Status != "" && best.Status != "Official" {
+		score -= unofficialPenalty
+	}
+
+	return score
+}
+
+// scoreMergedCandidate scores a provider-agnostic Candidate against the search
+// input. When the candidate carries a raw MusicBrainz payload (the common case,
+// since MusicBrainz is always searched first) it defers to scoreCandidate for the
+// fuller signal set, including the non-"Official" release penalty that isn't
+// representable on a merged Candidate alone.
+func (mb *MusicBrainzService) scoreMergedCandidate(input SearchParams, c Candidate) float64 {
+	if c.mbRecording != nil {
+		return mb.scoreCandidate(input, *c.mbRecording)
+	}
+
+	cleanTitle, _ := mb.cleaner.CleanRecording(input.Track)
+	score := titleWeight * normalizedSimilarity(strings.ToLower(cleanTitle), strings.ToLower(c.Title))
+
+	cleanArtist, _ := mb.cleaner.CleanArtist(input.Artist)
+	score += artistWeight * tokenSetRatio(cleanArtist, joinArtistNames(c.Artists))
+
+	if sim, ok := durationSimilarity(input.DurationMs, c.DurationMs); ok {
+		score += durationWeight * sim
+	}
+
+	if input.ISRC != "" && input.ISRC == c.ISRC {
+		score += isrcBonus
+	}
+
+	return score
+}
+
+func joinArtistCredit(credits []MusicBrainzArtistCredit) string {
+	var b strings.Builder
+	for _, c := range credits {
+		b.WriteString(c.Name)
+		b.WriteString(c.Joinphrase)
+	}
+	return b.String()
+}
+
+func joinArtistNames(artists []models.Artist) string {
+	names := make([]string, len(artists))
+	for i, a := range artists {
+		names[i] = a.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+// normalizedSimilarity turns Levenshtein edit distance into a 0..1 similarity
+// score, normalized by the longer of the two strings' rune count - levenshtein
+// operates on runes, so normalizing by byte length would badly skew non-ASCII
+// titles/artists (accented Latin, CJK, etc).
+func normalizedSimilarity(a, b string) float64 {
+	if a == "" && b == "" {
+		return 1
+	}
+	maxLen := utf8.RuneCountInString(a)
+	if rb := utf8.RuneCountInString(b); rb > maxLen {
+		maxLen = rb
+	}
+	if maxLen == 0 {
+		return 1
+	}
+	return 1 - float64(levenshtein(a, b))/float64(maxLen)
+}
+
+// releaseScore ranks a release for GetBestRelease selection among releases
+// GetBestRelease has already filtered to a single official/non-official group
+// (preferOfficialReleases) - official-vs-not is a hard gate, not an additive
+// term here, since letting it add up against the region/title bonuses below
+// would let those bonuses outweigh it and rank a non-official release first.
+func releaseScore(release MusicBrainzRelease, trackTitle string) float64 {
+	score := 1.0
+	if release.Country == "XW" || release.Country == "US" {
+		score += releaseRegionBonus
+	}
+	if release.Title != trackTitle {
+		score += releaseNotSelfTitledBonus
+	}
+	return score
+}
+
+// tokenSetRatio is a simplified token-set similarity: the Jaccard overlap between
+// the lowercased word sets of a and b. This is enough to tell "The Beatles" apart
+// from noise without pulling in a full fuzzy-matching library.
+func tokenSetRatio(a, b string) float64 {
+	setA := tokenSet(a)
+	setB := tokenSet(b)
+	if len(setA) == 0 && len(setB) == 0 {
+		return 1
+	}
+	if len(setA) == 0 || len(setB) == 0 {
+		return 0
+	}
+	intersection := 0
+	for tok := range setA {
+		if setB[tok] {
+			intersection++
+		}
+	}
+	union := len(setA) + len(setB) - intersection
+	return float64(intersection) / float64(union)
+}
+
+func tokenSet(s string) map[string]bool {
+	tokens := strings.Fields(strings.ToLower(s))
+	set := make(map[string]bool, len(tokens))
+	for _, t := range tokens {
+		set[strings.Trim(t, ",.-&()")] = true
+	}
+	return set
+}
+
+// durationSimilarity reports how close two durations are, as a 0..1 score, or
+// false if either is unknown (zero/negative).
+func durationSimilarity(inputMs, candidateMs int64) (float64, bool) {
+	if inputMs <= 0 || candidateMs <= 0 {
+		return 0, false
+	}
+	delta := math.Abs(float64(inputMs - candidateMs))
+	return 1 - math.Min(delta/durationToleranceMs, 1), true
+}
@@ -0,0 +1,82 @@
+package musicbrainz
+
+import "testing"
+
+func TestNormalizedSimilarity(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want float64
+	}{
+		{"both empty", "", "", 1},
+		{"identical", "hello", "hello", 1},
+		{"one empty", "hello", "", 0},
+		{"single edit out of four", "test", "text", 0.75},
+		{"non-ascii same rune count, full edit distance", "日本語歌", "abcd", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := normalizedSimilarity(tt.a, tt.b); got != tt.want {
+				t.Errorf("normalizedSimilarity(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTokenSetRatio(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want float64
+	}{
+		{"both empty", "", "", 1},
+		{"one empty", "the beatles", "", 0},
+		{"identical", "The Beatles", "the beatles", 1},
+		{"partial overlap", "The Beatles", "Beatles Fan Club", 0.25},
+		{"no overlap", "abc", "def", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tokenSetRatio(tt.a, tt.b); got != tt.want {
+				t.Errorf("tokenSetRatio(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReleaseScore(t *testing.T) {
+	// releaseScore only ranks within a group GetBestRelease has already filtered
+	// to a single official/non-official status (see preferOfficialReleases) - it
+	// doesn't look at Status itself.
+	trackTitle := "My Song"
+
+	us := MusicBrainzRelease{Country: "US", Title: "Greatest Hits"}
+	gb := MusicBrainzRelease{Country: "GB", Title: "Greatest Hits"}
+	if releaseScore(us, trackTitle) <= releaseScore(gb, trackTitle) {
+		t.Errorf("expected a US/XW release to outscore a non-preferred region")
+	}
+
+	selfTitled := MusicBrainzRelease{Title: trackTitle}
+	notSelfTitled := MusicBrainzRelease{Title: "Some Album"}
+	if releaseScore(selfTitled, trackTitle) >= releaseScore(notSelfTitled, trackTitle) {
+		t.Errorf("expected a non-self-titled release to score higher than a self-titled single")
+	}
+}
+
+func TestGetBestReleasePrefersOfficialOverBootlegDespiteSecondaryBonuses(t *testing.T) {
+	// Regression test: an Official release must never lose to a Bootleg release
+	// that happens to win both the region and self-title secondary bonuses -
+	// official status is a hard gate (preferOfficialReleases), not an additive
+	// term that those bonuses could outweigh.
+	releases := []MusicBrainzRelease{
+		{ID: "a", Status: "Official", Country: "GB", Title: "My Song"},
+		{ID: "b", Status: "Bootleg", Country: "US", Title: "Live Bootleg"},
+	}
+
+	best := GetBestRelease(releases, "My Song")
+	if best == nil || best.ID != "a" {
+		t.Fatalf("expected the Official release to win, got %+v", best)
+	}
+}
@@ -0,0 +1,29 @@
+package musicbrainz
+
+import "testing"
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want int
+	}{
+		{"both empty", "", "", 0},
+		{"a empty", "", "abc", 3},
+		{"b empty", "abc", "", 3},
+		{"identical", "kitten", "kitten", 0},
+		{"classic example", "kitten", "sitting", 3},
+		{"single substitution", "cat", "cot", 1},
+		{"case sensitive", "Cat", "cat", 1},
+		{"multi-byte runes", "café", "cafe", 1},
+		{"cjk edit distance", "東京都渋谷区", "大阪府大阪市", 6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := levenshtein(tt.a, tt.b); got != tt.want {
+				t.Errorf("levenshtein(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}